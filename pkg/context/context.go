@@ -0,0 +1,54 @@
+package context
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type targetKeyType struct{}
+
+var targetKey = targetKeyType{}
+
+// WithTarget returns a new context with the given target url.URL set.
+func WithTarget(ctx context.Context, target *url.URL) context.Context {
+	return context.WithValue(ctx, targetKey, target)
+}
+
+// TargetFrom looks in the given context and extracts a target url.URL, if found.
+func TargetFrom(ctx context.Context) *url.URL {
+	target := ctx.Value(targetKey)
+	if target != nil {
+		if t, ok := target.(*url.URL); ok {
+			return t
+		}
+	}
+	return nil
+}
+
+type headerKeyType struct{}
+
+var headerKey = headerKeyType{}
+
+// WithHeader returns a new context with the given header key/value added, merged with any
+// headers already set on ctx by a previous call to WithHeader.
+func WithHeader(ctx context.Context, key, value string) context.Context {
+	h := HeaderFrom(ctx).Clone()
+	if h == nil {
+		h = http.Header{}
+	}
+	h.Add(key, value)
+	return context.WithValue(ctx, headerKey, h)
+}
+
+// HeaderFrom looks in the given context and extracts the accumulated http.Header set by
+// WithHeader, if any. Returns nil if none is set.
+func HeaderFrom(ctx context.Context) http.Header {
+	h := ctx.Value(headerKey)
+	if h != nil {
+		if hh, ok := h.(http.Header); ok {
+			return hh
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Package auth provides pluggable request signing, validation, and transport wrappers used
+// by the http transport's auth-related ProtocolOptions (WithBasicAuth, WithRequestSigner,
+// WithRequestValidator, ...).
+package auth
+
+import "net/http"
+
+// RequestSigner signs an outgoing HTTP request in place, e.g. by adding an HMAC signature
+// header. Implementations must leave req.Body readable by the underlying RoundTripper once
+// Sign returns.
+type RequestSigner interface {
+	Sign(req *http.Request) error
+}
+
+// RequestValidator validates an incoming HTTP request before it is turned into a CloudEvent,
+// e.g. by checking a webhook signature. Implementations must leave req.Body readable by the
+// rest of the handler once Validate returns.
+type RequestValidator interface {
+	Validate(req *http.Request) error
+}
+
+// ValidationError is returned by a RequestValidator to control the HTTP status code used to
+// reject the request. A RequestValidator that returns a plain error instead causes the caller
+// to respond with http.StatusUnauthorized.
+type ValidationError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *ValidationError) Error() string { return e.Err.Error() }
+
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// SigningTransport wraps a base http.RoundTripper, invoking Signer.Sign on each outgoing
+// request before handing it to Base (or http.DefaultTransport if Base is nil).
+type SigningTransport struct {
+	Base   http.RoundTripper
+	Signer RequestSigner
+}
+
+func (t *SigningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if err := t.Signer.Sign(req); err != nil {
+		return nil, err
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// BasicAuthTransport wraps a base http.RoundTripper, setting HTTP Basic Auth credentials on
+// each outgoing request before handing it to Base (or http.DefaultTransport if Base is nil).
+type BasicAuthTransport struct {
+	Base     http.RoundTripper
+	Username string
+	Password string
+}
+
+func (t *BasicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(t.Username, t.Password)
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+
+	"github.com/cloudevents/sdk-go/pkg/transport/http/auth"
+)
+
+// WithBearerTokenSource wraps the client's Transport so every outgoing request carries an
+// "Authorization: Bearer <token>" header sourced from ts, refreshing as needed.
+func WithBearerTokenSource(ts oauth2.TokenSource) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http bearer token source option can not set nil protocol")
+		}
+		if ts == nil {
+			return fmt.Errorf("http bearer token source option was given a nil token source")
+		}
+		if p.Client == nil {
+			p.Client = &http.Client{}
+		}
+		p.Client.Transport = &oauth2.Transport{Source: ts, Base: p.Client.Transport}
+		return nil
+	}
+}
+
+// WithBasicAuth wraps the client's Transport so every outgoing request carries HTTP Basic
+// Auth credentials.
+func WithBasicAuth(user, pass string) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http basic auth option can not set nil protocol")
+		}
+		if p.Client == nil {
+			p.Client = &http.Client{}
+		}
+		p.Client.Transport = &auth.BasicAuthTransport{Base: p.Client.Transport, Username: user, Password: pass}
+		return nil
+	}
+}
+
+// WithTLSClientCert configures the client's Transport to present cert for mTLS, validating the
+// server against ca if non-nil (the system pool is used otherwise). Any existing auth/signing
+// wrapper already applied to the Transport (WithBearerTokenSource, WithBasicAuth,
+// WithRequestSigner, ...) is preserved regardless of the order options are given in.
+func WithTLSClientCert(cert tls.Certificate, ca *x509.CertPool) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http tls client cert option can not set nil protocol")
+		}
+		if p.Client == nil {
+			p.Client = &http.Client{}
+		}
+
+		transport, err := withHTTPTransport(p.Client.Transport, func(t *http.Transport) {
+			if t.TLSClientConfig == nil {
+				t.TLSClientConfig = &tls.Config{}
+			}
+			t.TLSClientConfig.Certificates = append(t.TLSClientConfig.Certificates, cert)
+			if ca != nil {
+				t.TLSClientConfig.RootCAs = ca
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("http tls client cert option: %w", err)
+		}
+		p.Client.Transport = transport
+		return nil
+	}
+}
+
+// withHTTPTransport walks a RoundTripper chain built by this package's auth options to find
+// the innermost *http.Transport, clones it, applies mutate to the clone, and reassembles the
+// chain with the mutated transport as the new innermost Base — preserving any outer
+// auth/signing wrappers instead of discarding them. If the chain has no *http.Transport yet,
+// mutate is applied to a fresh clone of http.DefaultTransport. Returns an error, rather than
+// silently leaving rt unchanged, if rt wraps a RoundTripper type this package doesn't know how
+// to recurse into, since that would otherwise make the caller a silent no-op.
+func withHTTPTransport(rt http.RoundTripper, mutate func(*http.Transport)) (http.RoundTripper, error) {
+	switch t := rt.(type) {
+	case nil:
+		base := http.DefaultTransport.(*http.Transport).Clone()
+		mutate(base)
+		return base, nil
+	case *http.Transport:
+		base := t.Clone()
+		mutate(base)
+		return base, nil
+	case *oauth2.Transport:
+		base, err := withHTTPTransport(t.Base, mutate)
+		if err != nil {
+			return nil, err
+		}
+		clone := *t
+		clone.Base = base
+		return &clone, nil
+	case *auth.BasicAuthTransport:
+		base, err := withHTTPTransport(t.Base, mutate)
+		if err != nil {
+			return nil, err
+		}
+		clone := *t
+		clone.Base = base
+		return &clone, nil
+	case *auth.SigningTransport:
+		base, err := withHTTPTransport(t.Base, mutate)
+		if err != nil {
+			return nil, err
+		}
+		clone := *t
+		clone.Base = base
+		return &clone, nil
+	default:
+		return nil, fmt.Errorf("unrecognized RoundTripper %T already set on client: refusing to silently discard it", rt)
+	}
+}
+
+// WithRequestSigner wraps the client's Transport so every outgoing request is signed by
+// signer before being sent, e.g. to add an HMAC webhook signature.
+func WithRequestSigner(signer auth.RequestSigner) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http request signer option can not set nil protocol")
+		}
+		if signer == nil {
+			return fmt.Errorf("http request signer option was given a nil signer")
+		}
+		if p.Client == nil {
+			p.Client = &http.Client{}
+		}
+		p.Client.Transport = &auth.SigningTransport{Base: p.Client.Transport, Signer: signer}
+		return nil
+	}
+}
+
+// WithRequestValidator runs v against every incoming request inside ServeHTTP, before the
+// message is pushed to Receive. A rejected request is answered with the status carried by an
+// *auth.ValidationError, or http.StatusUnauthorized otherwise.
+func WithRequestValidator(v auth.RequestValidator) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http request validator option can not set nil protocol")
+		}
+		if v == nil {
+			return fmt.Errorf("http request validator option was given a nil validator")
+		}
+		p.RequestValidator = v
+		return nil
+	}
+}
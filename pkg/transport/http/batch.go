@@ -0,0 +1,249 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+)
+
+// ContentTypeBatch is the CloudEvents HTTP batched content type, as defined by the CloudEvents
+// JSON batch format spec.
+const ContentTypeBatch = "application/cloudevents-batch+json"
+
+// WithMaxBatchSize caps the number of events ServeHTTP will decode out of a single
+// application/cloudevents-batch+json request, rejecting larger batches with
+// http.StatusRequestEntityTooLarge. Zero (the default) leaves batches uncapped.
+func WithMaxBatchSize(max int) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http max batch size option can not set nil protocol")
+		}
+		if max < 0 {
+			return fmt.Errorf("http max batch size option was given a negative size: %d", max)
+		}
+		p.MaxBatchSize = max
+		return nil
+	}
+}
+
+// WriteHttpRequestBatch serializes ms into req as a single CloudEvents JSON batch
+// (ContentTypeBatch), encoding each message in structured mode via WriteHttpRequest.
+func WriteHttpRequestBatch(ctx context.Context, ms []binding.Message, req *http.Request, transformers binding.TransformerFactories) error {
+	parts := make([]json.RawMessage, 0, len(ms))
+	for i, m := range ms {
+		part := &http.Request{Header: make(http.Header)}
+		if err := WriteHttpRequest(ctx, m, part, transformers); err != nil {
+			return fmt.Errorf("cloudevents-batch: message %d: %w", i, err)
+		}
+		if part.Body == nil {
+			continue
+		}
+		body, err := io.ReadAll(part.Body)
+		_ = part.Body.Close()
+		if err != nil {
+			return fmt.Errorf("cloudevents-batch: message %d: %w", i, err)
+		}
+		parts = append(parts, json.RawMessage(body))
+	}
+
+	payload, err := json.Marshal(parts)
+	if err != nil {
+		return err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(payload))
+	req.ContentLength = int64(len(payload))
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	req.Header.Set("Content-Type", ContentTypeBatch)
+	return nil
+}
+
+// SendBatch implements the batched variant of Send, encoding ms as a single CloudEvents JSON
+// batch request.
+func (p *Protocol) SendBatch(ctx context.Context, ms []binding.Message) error {
+	_, err := p.RequestBatch(ctx, ms)
+	return err
+}
+
+// RequestBatch is the batched variant of Request. It serializes ms into a single
+// application/cloudevents-batch+json request and returns the raw response, since a batch
+// response is an acknowledgement rather than a single CloudEvent.
+func (p *Protocol) RequestBatch(ctx context.Context, ms []binding.Message) (resp *http.Response, rErr error) {
+	defer func() {
+		for _, m := range ms {
+			_ = m.Finish(rErr)
+		}
+	}()
+
+	if p.Client == nil {
+		rErr = fmt.Errorf("not initialized: %#v", p)
+		return nil, rErr
+	}
+
+	if p.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.RequestTimeout)
+		defer cancel()
+	}
+
+	req, reqErr := p.makeRequest(ctx)
+	if reqErr != nil {
+		rErr = reqErr
+		return nil, rErr
+	}
+
+	// Trace each message independently before encoding, same as Request, so the
+	// traceparent/tracestate extension attributes survive the batch's structured encoding
+	// rather than being patched onto the (single, shared) HTTP request's headers afterward.
+	tracedMs := make([]binding.Message, len(ms))
+	for i, m := range ms {
+		tracedMs[i] = withTracingExtensions(ctx, m)
+	}
+
+	if rErr = WriteHttpRequestBatch(ctx, tracedMs, req, p.transformers); rErr != nil {
+		return nil, rErr
+	}
+	injectTraceContext(ctx, req)
+
+	resp, rErr = p.Client.Do(req)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if resp.StatusCode/100 != 2 {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+		rErr = fmt.Errorf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		return nil, rErr
+	}
+	return resp, nil
+}
+
+// isBatchRequest reports whether req carries the CloudEvents batched content type.
+func isBatchRequest(req *http.Request) bool {
+	ct := req.Header.Get("Content-Type")
+	if i := bytes.IndexByte([]byte(ct), ';'); i >= 0 {
+		ct = ct[:i]
+	}
+	return ct == ContentTypeBatch
+}
+
+// maxBatchEventBytes is the per-event allowance used to size the MaxBytesReader backing
+// WithMaxBatchSize. The exact per-event size isn't known up front, so this is a generous but
+// finite allowance rather than a precise limit.
+const maxBatchEventBytes = 1 << 20 // 1MiB
+
+// errBatchTooLarge is returned by decodeBatchParts when r's batch has more than maxParts
+// elements, distinguishing that case from an ordinary parse failure so the caller can answer
+// with http.StatusRequestEntityTooLarge rather than http.StatusBadRequest.
+type errBatchTooLarge struct {
+	max int
+}
+
+func (e *errBatchTooLarge) Error() string {
+	return fmt.Sprintf("cloudevents batch exceeds max batch size %d", e.max)
+}
+
+// decodeBatchParts decodes r as a CloudEvents JSON batch array into its individual element
+// JSON documents, as a token stream rather than a buffered unmarshal, so maxParts (when
+// positive) aborts an oversized batch before r has been read in full. maxParts <= 0 leaves the
+// element count uncapped.
+func decodeBatchParts(r io.Reader, maxParts int) ([]json.RawMessage, error) {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse cloudevents batch: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("cloudevents batch body must be a JSON array")
+	}
+
+	var parts []json.RawMessage
+	for dec.More() {
+		if maxParts > 0 && len(parts) >= maxParts {
+			return nil, &errBatchTooLarge{max: maxParts}
+		}
+		var part json.RawMessage
+		if err := dec.Decode(&part); err != nil {
+			return nil, fmt.Errorf("cannot parse cloudevents batch: %w", err)
+		}
+		parts = append(parts, part)
+	}
+	if _, err := dec.Token(); err != nil { // closing ']'
+		return nil, fmt.Errorf("cannot parse cloudevents batch: %w", err)
+	}
+	return parts, nil
+}
+
+// serveHTTPBatch decodes a CloudEvents JSON batch request into individual binding.Messages,
+// pushing each onto incoming. The response is only written once every message's Finish has
+// been called. It returns the error it reported to the client (a parse failure, an oversized
+// batch, or the first non-nil error passed to any part's Finish), or nil on success, so the caller can fold the
+// outcome into its own span/metric recording rather than treating every batch as successful.
+//
+// The body is read and decoded as a stream rather than buffered and unmarshalled whole, so
+// WithMaxBatchSize aborts an oversized batch before it is ever fully read into memory.
+func (p *Protocol) serveHTTPBatch(rw http.ResponseWriter, req *http.Request) error {
+	body := req.Body
+	if p.MaxBatchSize > 0 {
+		body = http.MaxBytesReader(rw, body, int64(p.MaxBatchSize)*maxBatchEventBytes)
+	}
+	defer body.Close()
+
+	parts, err := decodeBatchParts(body, p.MaxBatchSize)
+	if err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *errBatchTooLarge
+		if errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(rw, err.Error(), status)
+		return err
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	wg.Add(len(parts))
+
+	for _, part := range parts {
+		partReq := req.Clone(req.Context())
+		partReq.Header = req.Header.Clone()
+		partReq.Header.Set("Content-Type", "application/cloudevents+json")
+		partReq.Body = io.NopCloser(bytes.NewReader(part))
+		partReq.ContentLength = int64(len(part))
+
+		m := NewMessageFromHttpRequest(partReq)
+		m.OnFinish = func(err error) error {
+			defer wg.Done()
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+			return nil
+		}
+		p.incoming <- msgErr{m, nil}
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		firstErr = fmt.Errorf("cannot forward CloudEvent batch: %w", firstErr)
+		http.Error(rw, firstErr.Error(), http.StatusInternalServerError)
+		return firstErr
+	}
+	rw.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeBatchParts(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		maxParts  int
+		wantCount int
+		wantErr   bool
+	}{
+		{"empty array", `[]`, 0, 0, false},
+		{"two events under an uncapped limit", `[{"id":"1"},{"id":"2"}]`, 0, 2, false},
+		{"exactly at the cap is allowed", `[{"id":"1"},{"id":"2"}]`, 2, 2, false},
+		{"not a JSON array", `{"id":"1"}`, 0, 0, true},
+		{"not JSON at all", `not json`, 0, 0, true},
+		{"malformed element", `[{"id":"1"}, not json]`, 0, 0, true},
+		{"truncated array, missing closing bracket", `[{"id":"1"}`, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := decodeBatchParts(strings.NewReader(tt.body), tt.maxParts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("decodeBatchParts() = %v, %v; want an error", parts, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeBatchParts() unexpected error: %v", err)
+			}
+			if len(parts) != tt.wantCount {
+				t.Fatalf("decodeBatchParts() returned %d parts, want %d", len(parts), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestDecodeBatchPartsOverCap(t *testing.T) {
+	_, err := decodeBatchParts(strings.NewReader(`[{"id":"1"},{"id":"2"},{"id":"3"}]`), 2)
+	if err == nil {
+		t.Fatal("decodeBatchParts() = nil error, want errBatchTooLarge")
+	}
+	var tooLarge *errBatchTooLarge
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("decodeBatchParts() error = %v (%T), want *errBatchTooLarge", err, err)
+	}
+	if tooLarge.max != 2 {
+		t.Fatalf("errBatchTooLarge.max = %d, want 2", tooLarge.max)
+	}
+}
+
+func TestIsBatchRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        bool
+	}{
+		{"exact batch content type", ContentTypeBatch, true},
+		{"batch content type with charset parameter", ContentTypeBatch + "; charset=utf-8", true},
+		{"single event content type", "application/cloudevents+json", false},
+		{"empty content type", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if got := isBatchRequest(req); got != tt.want {
+				t.Fatalf("isBatchRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
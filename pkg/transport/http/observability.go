@@ -0,0 +1,149 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cloudevents/sdk-go/pkg/binding"
+)
+
+const instrumentationName = "github.com/cloudevents/sdk-go/pkg/transport/http"
+
+var (
+	// Tracer is the process-wide default tracer used by a Protocol that hasn't been given its
+	// own via WithObservability (a no-op tracer out of the box). It is never written to by
+	// Protocol construction; set it yourself at startup if you want every unconfigured
+	// Protocol, and transformers that reference it directly, to share a real TracerProvider.
+	Tracer trace.Tracer = trace.NewNoopTracerProvider().Tracer(instrumentationName)
+
+	// Meter is the process-wide default meter, analogous to Tracer.
+	Meter metric.Meter = metric.NewNoopMeterProvider().Meter(instrumentationName)
+
+	tracePropagator = propagation.TraceContext{}
+)
+
+// WithObservability enables OpenTelemetry tracing and metrics on this Protocol instance only,
+// deriving its tracer/meter from tp and mp. Other Protocol instances, configured or not, are
+// unaffected.
+//
+// Request starts a client span covering the outbound call (including retries), injects W3C
+// traceparent/tracestate into the outgoing request, and records latency/count metrics. The
+// CloudEvents distributed tracing extension attributes (ce-traceparent/ce-tracestate) are
+// populated from the active span when not already set on the message.
+//
+// ServeHTTP extracts the incoming trace context, starts a server span covering the full
+// Message.Finish lifecycle, and records receive metrics.
+func WithObservability(tp trace.TracerProvider, mp metric.MeterProvider) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http observability option can not set nil protocol")
+		}
+		if tp == nil {
+			return fmt.Errorf("http observability option was given a nil TracerProvider")
+		}
+		if mp == nil {
+			return fmt.Errorf("http observability option was given a nil MeterProvider")
+		}
+
+		p.tracer = tp.Tracer(instrumentationName)
+		meter := mp.Meter(instrumentationName)
+
+		var err error
+		if p.requestDuration, err = meter.Float64Histogram("cloudevents.http.client.duration"); err != nil {
+			return err
+		}
+		if p.requestCount, err = meter.Int64Counter("cloudevents.http.client.count"); err != nil {
+			return err
+		}
+		if p.receiveDuration, err = meter.Float64Histogram("cloudevents.http.server.duration"); err != nil {
+			return err
+		}
+		if p.receiveCount, err = meter.Int64Counter("cloudevents.http.server.count"); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+// injectTraceContext injects ctx's span context into req's headers as the standard W3C
+// traceparent/tracestate headers. The CloudEvents distributed tracing extension attributes are
+// handled separately, by withTracingExtensions, since they must be set on the event itself to
+// survive structured and batched encodings rather than only the binary, header-based one.
+func injectTraceContext(ctx context.Context, req *http.Request) {
+	tracePropagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+// withTracingExtensions returns m unchanged unless ctx carries a valid span context, in which
+// case it materializes m as an event.Event via binding.ToEvent and returns a new message
+// wrapping that event with the traceparent/tracestate distributed tracing extension attributes
+// set (when not already present on the event). Applying the extensions to the event model
+// itself, rather than patching ce-traceparent/ce-tracestate HTTP headers after WriteHttpRequest
+// has already encoded the message, means the attributes survive regardless of whether the
+// message ends up encoded as binary, structured, or as part of a batch.
+func withTracingExtensions(ctx context.Context, m binding.Message) binding.Message {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return m
+	}
+
+	e, err := binding.ToEvent(ctx, m)
+	if err != nil {
+		return m
+	}
+
+	if _, ok := e.Extensions()["traceparent"]; !ok {
+		_ = e.SetExtension("traceparent", formatTraceParent(sc))
+	}
+	if ts := sc.TraceState().String(); ts != "" {
+		if _, ok := e.Extensions()["tracestate"]; !ok {
+			_ = e.SetExtension("tracestate", ts)
+		}
+	}
+
+	em := binding.EventMessage(*e)
+	return &em
+}
+
+// formatTraceParent renders sc as a W3C traceparent header value, matching the format used by
+// propagation.TraceContext, for use as the CloudEvents traceparent extension attribute.
+func formatTraceParent(sc trace.SpanContext) string {
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID(), sc.SpanID(), sc.TraceFlags()&trace.FlagsSampled)
+}
+
+// extractTraceContext returns a context carrying the span context described by req's
+// traceparent/tracestate headers, falling back to the CloudEvents ce-traceparent/ce-tracestate
+// extension headers when the standard ones are absent.
+func extractTraceContext(ctx context.Context, req *http.Request) context.Context {
+	h := req.Header
+	if h.Get("traceparent") == "" && h.Get("ce-traceparent") != "" {
+		h = h.Clone()
+		h.Set("traceparent", h.Get("ce-traceparent"))
+		if ts := h.Get("ce-tracestate"); ts != "" {
+			h.Set("tracestate", ts)
+		}
+	}
+	return tracePropagator.Extract(ctx, propagation.HeaderCarrier(h))
+}
+
+func httpStatusAttribute(resp *http.Response) attribute.KeyValue {
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	return attribute.Int("http.status_code", status)
+}
+
+func recordSpanError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
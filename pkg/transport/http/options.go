@@ -0,0 +1,98 @@
+package http
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ProtocolOption is the function signature required to be considered an http.ProtocolOption.
+type ProtocolOption func(*Protocol) error
+
+// WithPort sets the port to bind the receiver to when Listener is not set explicitly.
+// Only used by StartReceiver; it has no effect when the Protocol is mounted as an http Handler
+// on a server built elsewhere.
+func WithPort(port int) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http port option can not set nil protocol")
+		}
+		if port < 0 || port > 65535 {
+			return fmt.Errorf("http port option was given an invalid port: %d", port)
+		}
+		p.Port = port
+		return nil
+	}
+}
+
+// WithPath sets the path to receive cloudevents on for HTTP requests.
+func WithPath(path string) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http path option can not set nil protocol")
+		}
+		if path == "" {
+			return fmt.Errorf("http path option was given an invalid path: %q", path)
+		}
+		p.Path = path
+		return nil
+	}
+}
+
+// WithListener sets the listener for the StartReceiver to use instead of creating one from Port.
+func WithListener(l net.Listener) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http listener option can not set nil protocol")
+		}
+		p.Listener = l
+		return nil
+	}
+}
+
+// WithShutdownTimeout sets the timeout given to http.Server.Shutdown when StartReceiver's
+// context is cancelled. Defaults to DefaultShutdownTimeout.
+func WithShutdownTimeout(timeout time.Duration) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http shutdown timeout option can not set nil protocol")
+		}
+		p.ShutdownTimeout = timeout
+		return nil
+	}
+}
+
+// WithHeader adds a static header sent on every outgoing request, in addition to any
+// RequestTemplate headers and per-call headers attached to the context via cecontext.WithHeader.
+func WithHeader(key, value string) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http header option can not set nil protocol")
+		}
+		if key == "" {
+			return fmt.Errorf("http header option was given an empty header key")
+		}
+		if p.Headers == nil {
+			p.Headers = http.Header{}
+		}
+		p.Headers.Add(key, value)
+		return nil
+	}
+}
+
+// WithMiddleware adds an http.Handler middleware that wraps the Protocol's ServeHTTP
+// when serving via StartReceiver. Middlewares are applied in the order they are given,
+// with the first one being the outermost wrapper.
+func WithMiddleware(middleware func(next http.Handler) http.Handler) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http middleware option can not set nil protocol")
+		}
+		if middleware == nil {
+			return fmt.Errorf("http middleware option was given a nil middleware")
+		}
+		p.middlewares = append(p.middlewares, middleware)
+		return nil
+	}
+}
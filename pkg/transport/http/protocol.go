@@ -2,19 +2,31 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/cloudevents/sdk-go/pkg/binding"
 	cecontext "github.com/cloudevents/sdk-go/pkg/context"
+	"github.com/cloudevents/sdk-go/pkg/transport/http/auth"
 )
 
 const (
 	// DefaultShutdownTimeout defines the default timeout given to the http.Server when calling Shutdown.
 	DefaultShutdownTimeout = time.Minute * 1
+
+	// DefaultPort is the port used by StartReceiver when neither Port nor Listener is set.
+	DefaultPort = 8080
 )
 
 // Protocol acts as both a http client and a http handler.
@@ -24,12 +36,67 @@ type Protocol struct {
 	transformers    binding.TransformerFactories
 	Client          *http.Client
 	incoming        chan msgErr
+
+	// Port and Path are used by StartReceiver to build the listening address and mux
+	// route, unless Listener is set directly.
+	Port int
+	Path string
+
+	// Listener, when set, is used in place of a listener built from Port for StartReceiver.
+	Listener net.Listener
+
+	// ShutdownTimeout is passed to http.Server.Shutdown when StartReceiver's context is
+	// cancelled. Defaults to DefaultShutdownTimeout.
+	ShutdownTimeout time.Duration
+
+	middlewares []func(http.Handler) http.Handler
+
+	server *http.Server
+
+	// MaxRetries is the number of additional attempts made by Request after an initial
+	// attempt deemed retryable by RetryPolicy. Zero (the default) disables retries.
+	MaxRetries int
+	// InitialBackoff is the base delay before the first retry. Defaults to 500ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponentially growing delay between retries. Zero means uncapped.
+	MaxBackoff time.Duration
+	// Jitter is the fraction (0-1) of the backoff delay to randomize away with full jitter.
+	Jitter float64
+	// RetryPolicy decides which attempts are retryable. Defaults to defaultRetryPolicy.
+	RetryPolicy RetryPolicy
+
+	// Headers are static headers added to every outgoing request, set via WithHeader.
+	Headers http.Header
+
+	// RequestValidator, when set, is run against every incoming request inside ServeHTTP,
+	// before the message is pushed to Receive. Set via WithRequestValidator.
+	RequestValidator auth.RequestValidator
+
+	// tracer is this Protocol's tracer, set to the package-level default Tracer at
+	// construction and overridden per-instance by WithObservability.
+	tracer trace.Tracer
+
+	// requestDuration, requestCount, receiveDuration and receiveCount are populated by
+	// WithObservability and left nil (instrumentation disabled) otherwise.
+	requestDuration metric.Float64Histogram
+	requestCount    metric.Int64Counter
+	receiveDuration metric.Float64Histogram
+	receiveCount    metric.Int64Counter
+
+	// MaxBatchSize caps the number of events ServeHTTP will decode out of a batch request.
+	// Set via WithMaxBatchSize. Zero means uncapped.
+	MaxBatchSize int
+
+	// RequestTimeout bounds the whole of a single Request/RequestBatch call, including any
+	// retries. Set via WithRequestTimeout. Zero means no timeout beyond the caller's ctx.
+	RequestTimeout time.Duration
 }
 
 func NewProtocol(opts ...ProtocolOption) (*Protocol, error) {
 	p := &Protocol{
 		transformers: make(binding.TransformerFactories, 0),
 		incoming:     make(chan msgErr),
+		tracer:       Tracer,
 	}
 	if err := p.applyOptions(opts...); err != nil {
 		return nil, err
@@ -57,55 +124,165 @@ func (p *Protocol) Send(ctx context.Context, m binding.Message) error {
 	return err
 }
 
-// Request implements binding.Requester
-func (p *Protocol) Request(ctx context.Context, m binding.Message) (binding.Message, error) {
-	var err error
-	defer func() { _ = m.Finish(err) }()
+// Request implements binding.Requester.
+// If ctx carries a *RetriesResult (see ContextWithRetriesResult), it is populated with one
+// RetryAttempt per attempt made, including the final one.
+func (p *Protocol) Request(ctx context.Context, m binding.Message) (rMsg binding.Message, rErr error) {
+	defer func() { _ = m.Finish(rErr) }()
 
-	req := p.makeRequest(ctx)
+	if p.Client == nil {
+		rErr = fmt.Errorf("not initialized: %#v", p)
+		return nil, rErr
+	}
 
-	if p.Client == nil || req == nil || req.URL == nil {
-		return nil, fmt.Errorf("not initialized: %#v", p)
+	if p.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.RequestTimeout)
+		defer cancel()
 	}
 
-	if err = WriteHttpRequest(ctx, m, req, p.transformers); err != nil {
-		return nil, err
+	var span trace.Span
+	ctx, span = p.tracer.Start(ctx, "cloudevents.http.Request", trace.WithSpanKind(trace.SpanKindClient))
+	requestStart := time.Now()
+	var resp *http.Response
+	defer func() {
+		recordSpanError(span, rErr)
+		span.SetAttributes(httpStatusAttribute(resp))
+		span.End()
+		if p.requestDuration != nil {
+			p.requestDuration.Record(ctx, time.Since(requestStart).Seconds())
+		}
+		if p.requestCount != nil {
+			p.requestCount.Add(ctx, 1, attribute.Bool("error", rErr != nil))
+		}
+	}()
+
+	result := retriesResultFrom(ctx)
+	policy := p.RetryPolicy
+	if policy == nil {
+		policy = defaultRetryPolicy{}
+	}
+	backoff := p.InitialBackoff
+	if backoff == 0 {
+		backoff = defaultInitialBackoff
 	}
-	resp, err := p.Client.Do(req)
+
+	// Computed once, outside the retry loop: withTracingExtensions may materialize m as an
+	// event.Event, and every attempt should encode that same traced event rather than
+	// re-deriving (or inconsistently tracing) it per attempt.
+	encMsg := withTracingExtensions(ctx, m)
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		req, reqErr := p.makeRequest(ctx)
+		if reqErr != nil {
+			rErr = reqErr
+			return nil, rErr
+		}
+
+		if err = WriteHttpRequest(ctx, encMsg, req, p.transformers); err != nil {
+			rErr = err
+			return nil, rErr
+		}
+		injectTraceContext(ctx, req)
+
+		start := time.Now()
+		resp, err = p.Client.Do(req)
+		duration := time.Since(start)
+
+		if result != nil {
+			statusCode := 0
+			if resp != nil {
+				statusCode = resp.StatusCode
+			}
+			result.Attempts = append(result.Attempts, RetryAttempt{StatusCode: statusCode, Err: err, Duration: duration})
+		}
+
+		if attempt >= p.MaxRetries || !policy.IsRetryable(resp, err) {
+			break
+		}
+
+		wait := retryAfter(resp)
+		if resp != nil && resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+		if wait == 0 {
+			wait = fullJitterBackoff(backoff, p.Jitter)
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			rErr = ctx.Err()
+			return nil, rErr
+		}
+
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+
 	if err != nil {
-		return nil, err
+		rErr = err
+		return nil, rErr
 	}
 	if resp.StatusCode/100 != 2 {
-		return nil, fmt.Errorf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		rErr = fmt.Errorf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		return nil, rErr
 	}
 
 	return NewMessage(resp.Header, resp.Body), nil
 }
 
-func (p *Protocol) makeRequest(ctx context.Context) *http.Request {
-	// TODO: support custom headers from context?
-	req := &http.Request{
-		Header: make(http.Header),
-		// TODO: HeaderFrom(ctx),
-	}
+func (p *Protocol) makeRequest(ctx context.Context) (*http.Request, error) {
+	var method string
+	var target *url.URL
+	var reqClose bool
+	var host string
+	header := make(http.Header)
 
 	if p.RequestTemplate != nil {
-		req.Method = p.RequestTemplate.Method
-		req.URL = p.RequestTemplate.URL
-		req.Close = p.RequestTemplate.Close
-		req.Host = p.RequestTemplate.Host
-		copyHeadersEnsure(p.RequestTemplate.Header, &req.Header)
+		method = p.RequestTemplate.Method
+		target = p.RequestTemplate.URL
+		reqClose = p.RequestTemplate.Close
+		host = p.RequestTemplate.Host
+		copyHeadersEnsure(p.RequestTemplate.Header, &header)
 	}
 
+	copyHeadersEnsure(p.Headers, &header)
+
 	if p.Target != nil {
-		req.URL = p.Target
+		target = p.Target
 	}
 
 	// Override the default request with target from context.
-	if target := cecontext.TargetFrom(ctx); target != nil {
-		req.URL = target
+	if t := cecontext.TargetFrom(ctx); t != nil {
+		target = t
+	}
+
+	// Merge any per-call headers attached to ctx; their values are added after any
+	// template/static values for the same key rather than replacing them.
+	copyHeadersEnsure(cecontext.HeaderFrom(ctx), &header)
+
+	if target == nil {
+		return nil, fmt.Errorf("not initialized: %#v", p)
+	}
+
+	// Using NewRequestWithContext (rather than building the Request by hand and calling
+	// WithContext after the fact) ensures ctx is wired in before any Dial, so cancellation
+	// during DNS/TCP setup is respected.
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Close = reqClose
+	if host != "" {
+		req.Host = host
 	}
-	return req.WithContext(ctx)
+	req.Header = header
+
+	return req, nil
 }
 
 // Ensure to is a non-nil map before copying
@@ -145,10 +322,124 @@ type msgErr struct {
 	err error
 }
 
+// StartReceiver starts an http.Server bound to p.Listener (or a listener built from p.Port
+// if unset), mounting p on p.Path (or "/" if unset) and wrapping it with any middleware
+// registered via WithMiddleware. It blocks until ctx is cancelled, at which point it calls
+// Shutdown with p.ShutdownTimeout (or DefaultShutdownTimeout), waits for in-flight ServeHTTP
+// calls to drain, and closes the incoming channel so Receive returns io.EOF.
+// StartReceiver must not be called more than once.
+func (p *Protocol) StartReceiver(ctx context.Context) error {
+	if p.server != nil {
+		return fmt.Errorf("http protocol already has a receiver running")
+	}
+
+	listener := p.Listener
+	if listener == nil {
+		var err error
+		listener, err = net.Listen("tcp", ":"+strconv.Itoa(p.listenPort()))
+		if err != nil {
+			return err
+		}
+	}
+
+	path := p.Path
+	if path == "" {
+		path = "/"
+	}
+
+	// inFlight tracks ServeHTTP calls currently running. Server.Shutdown only waits for
+	// connections to go idle, and returns once its own timeout elapses regardless of whether
+	// that has happened yet, so a ServeHTTP goroutine can still be blocked sending into
+	// p.incoming when Shutdown returns. Closing p.incoming before that send completes would
+	// panic, so inFlight.Wait() is used to make sure it's actually safe first.
+	var inFlight sync.WaitGroup
+	mux := http.NewServeMux()
+	mux.Handle(path, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		inFlight.Add(1)
+		defer inFlight.Done()
+		p.ServeHTTP(rw, req)
+	}))
+
+	var handler http.Handler = mux
+	for i := len(p.middlewares) - 1; i >= 0; i-- {
+		handler = p.middlewares[i](handler)
+	}
+
+	p.server = &http.Server{Handler: handler}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := p.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case err := <-errCh:
+		inFlight.Wait()
+		close(p.incoming)
+		return err
+	case <-ctx.Done():
+	}
+
+	timeout := p.ShutdownTimeout
+	if timeout == 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := p.server.Shutdown(shutdownCtx)
+	<-errCh
+	inFlight.Wait()
+	close(p.incoming)
+	return err
+}
+
+func (p *Protocol) listenPort() int {
+	if p.Port != 0 {
+		return p.Port
+	}
+	return DefaultPort
+}
+
 // ServeHTTP implements http.Handler.
 // Blocks until Message.Finish is called.
 func (p *Protocol) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	var err error
+
+	if p.RequestValidator != nil {
+		if verr := p.RequestValidator.Validate(req); verr != nil {
+			status := http.StatusUnauthorized
+			var ve *auth.ValidationError
+			if errors.As(verr, &ve) && ve.StatusCode != 0 {
+				status = ve.StatusCode
+			}
+			http.Error(rw, fmt.Sprintf("request validation failed: %v", verr), status)
+			return
+		}
+	}
+
+	ctx := extractTraceContext(req.Context(), req)
+	ctx, span := p.tracer.Start(ctx, "cloudevents.http.Receive", trace.WithSpanKind(trace.SpanKindServer))
+	receiveStart := time.Now()
+	req = req.WithContext(ctx)
+
+	if isBatchRequest(req) {
+		batchErr := p.serveHTTPBatch(rw, req)
+		recordSpanError(span, batchErr)
+		span.End()
+		if p.receiveDuration != nil {
+			p.receiveDuration.Record(ctx, time.Since(receiveStart).Seconds())
+		}
+		if p.receiveCount != nil {
+			p.receiveCount.Add(ctx, 1, attribute.Bool("batch", true), attribute.Bool("error", batchErr != nil))
+		}
+		return
+	}
+
 	m := NewMessageFromHttpRequest(req)
 	if m.ReadEncoding() == binding.EncodingUnknown {
 		p.incoming <- msgErr{nil, binding.ErrUnknownEncoding}
@@ -160,6 +451,16 @@ func (p *Protocol) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 			_ = m.resp.Finish(err)
 		}
 		m.resp = nil
+
+		recordSpanError(span, err)
+		span.End()
+		if p.receiveDuration != nil {
+			p.receiveDuration.Record(ctx, time.Since(receiveStart).Seconds())
+		}
+		if p.receiveCount != nil {
+			p.receiveCount.Add(ctx, 1, attribute.Bool("error", err != nil))
+		}
+
 		done <- err
 		return nil
 	}
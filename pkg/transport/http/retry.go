@@ -0,0 +1,136 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultInitialBackoff is used by Request when WithRetries was not given an initial backoff.
+const defaultInitialBackoff = 500 * time.Millisecond
+
+// RetryPolicy decides whether a given attempt's response/error should be retried.
+// The default policy used when none is configured retries network errors and 5xx/429
+// responses.
+type RetryPolicy interface {
+	IsRetryable(resp *http.Response, err error) bool
+}
+
+type defaultRetryPolicy struct{}
+
+func (defaultRetryPolicy) IsRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	if resp == nil {
+		return true
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode/100 == 5
+}
+
+// RetryAttempt records the outcome of a single attempt made by Protocol.Request.
+type RetryAttempt struct {
+	StatusCode int
+	Err        error
+	Duration   time.Duration
+}
+
+// RetriesResult collects one RetryAttempt per attempt Protocol.Request makes, including the
+// final one. Attach it to a context passed to Request with ContextWithRetriesResult.
+type RetriesResult struct {
+	Attempts []RetryAttempt
+}
+
+type retriesResultKey struct{}
+
+// ContextWithRetriesResult returns a copy of ctx that causes Protocol.Request to append a
+// RetryAttempt to result for every attempt it makes.
+func ContextWithRetriesResult(ctx context.Context, result *RetriesResult) context.Context {
+	return context.WithValue(ctx, retriesResultKey{}, result)
+}
+
+func retriesResultFrom(ctx context.Context) *RetriesResult {
+	result, _ := ctx.Value(retriesResultKey{}).(*RetriesResult)
+	return result
+}
+
+// WithRetries configures Protocol.Request to retry failed attempts up to max additional times,
+// using exponential backoff with full jitter between initialBackoff and maxBackoff (zero means
+// uncapped). jitter is the fraction, between 0 and 1, of the backoff delay that is randomized
+// away. Use WithRetryPolicy to customize which attempts are considered retryable.
+func WithRetries(max int, initialBackoff, maxBackoff time.Duration, jitter float64) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http retries option can not set nil protocol")
+		}
+		if max < 0 {
+			return fmt.Errorf("http retries option was given a negative max: %d", max)
+		}
+		p.MaxRetries = max
+		p.InitialBackoff = initialBackoff
+		p.MaxBackoff = maxBackoff
+		p.Jitter = jitter
+		return nil
+	}
+}
+
+// WithRetryPolicy overrides the RetryPolicy used by Protocol.Request. If not given, a policy
+// that retries network errors and 5xx/429 responses is used.
+func WithRetryPolicy(policy RetryPolicy) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http retry policy option can not set nil protocol")
+		}
+		if policy == nil {
+			return fmt.Errorf("http retry policy option was given a nil policy")
+		}
+		p.RetryPolicy = policy
+		return nil
+	}
+}
+
+// fullJitterBackoff returns a random duration in [(1-jitter)*backoff, backoff], implementing
+// the "full jitter" strategy scaled by jitter (0 disables randomization).
+func fullJitterBackoff(backoff time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return backoff
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	floor := float64(backoff) * (1 - jitter)
+	spread := float64(backoff) * jitter
+	return time.Duration(floor + rand.Float64()*spread)
+}
+
+// retryAfter parses the Retry-After header from resp, supporting both the delay-seconds and
+// HTTP-date forms. Returns zero if resp is nil or the header is absent/unparseable.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			return 0
+		}
+		return d
+	}
+	return 0
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFullJitterBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff time.Duration
+		jitter  float64
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{"zero jitter returns backoff unchanged", time.Second, 0, time.Second, time.Second},
+		{"negative jitter treated as zero", time.Second, -1, time.Second, time.Second},
+		{"full jitter stays within [0, backoff]", time.Second, 1, 0, time.Second},
+		{"jitter above 1 is clamped to 1", time.Second, 2, 0, time.Second},
+		{"partial jitter stays within [(1-jitter)*backoff, backoff]", time.Second, 0.5, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				got := fullJitterBackoff(tt.backoff, tt.jitter)
+				if got < tt.wantMin || got > tt.wantMax {
+					t.Fatalf("fullJitterBackoff(%s, %v) = %s, want in [%s, %s]", tt.backoff, tt.jitter, got, tt.wantMin, tt.wantMax)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *http.Response
+		want time.Duration
+	}{
+		{"nil response", nil, 0},
+		{"no header", &http.Response{Header: http.Header{}}, 0},
+		{"delay-seconds form", &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}, 5 * time.Second},
+		{"negative delay-seconds treated as zero", &http.Response{Header: http.Header{"Retry-After": []string{"-5"}}}, 0},
+		{"unparseable value", &http.Response{Header: http.Header{"Retry-After": []string{"not-a-time"}}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryAfter(tt.resp); got != tt.want {
+				t.Fatalf("retryAfter() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC()
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{future.Format(http.TimeFormat)}}}
+
+	got := retryAfter(resp)
+	if got <= 0 || got > 10*time.Second {
+		t.Fatalf("retryAfter() = %s, want in (0, 10s]", got)
+	}
+}
+
+func TestRetryAfterPastHTTPDate(t *testing.T) {
+	past := time.Now().Add(-10 * time.Second).UTC()
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{past.Format(http.TimeFormat)}}}
+
+	if got := retryAfter(resp); got != 0 {
+		t.Fatalf("retryAfter() = %s, want 0 for a time already in the past", got)
+	}
+}
+
+func TestDefaultRetryPolicyIsRetryable(t *testing.T) {
+	policy := defaultRetryPolicy{}
+
+	tests := []struct {
+		name string
+		resp *http.Response
+		err  error
+		want bool
+	}{
+		{"network error", nil, errTest{}, true},
+		{"nil response, no error", nil, nil, true},
+		{"5xx is retryable", &http.Response{StatusCode: http.StatusInternalServerError}, nil, true},
+		{"429 is retryable", &http.Response{StatusCode: http.StatusTooManyRequests}, nil, true},
+		{"4xx other than 429 is not retryable", &http.Response{StatusCode: http.StatusBadRequest}, nil, false},
+		{"2xx is not retryable", &http.Response{StatusCode: http.StatusOK}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.IsRetryable(tt.resp, tt.err); got != tt.want {
+				t.Fatalf("IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type errTest struct{}
+
+func (errTest) Error() string { return "test error" }
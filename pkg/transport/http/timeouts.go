@@ -0,0 +1,52 @@
+package http
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// WithRequestTimeout bounds the whole of a single Request/RequestBatch call, including any
+// retries, by wrapping the context passed in with context.WithTimeout.
+func WithRequestTimeout(d time.Duration) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http request timeout option can not set nil protocol")
+		}
+		if d <= 0 {
+			return fmt.Errorf("http request timeout option was given a non-positive duration: %s", d)
+		}
+		p.RequestTimeout = d
+		return nil
+	}
+}
+
+// WithConnectTimeout configures the client's Transport with a DialContext that bounds DNS
+// resolution and TCP connection establishment to d, independent of any overall request timeout.
+// It can be combined freely with WithBearerTokenSource, WithBasicAuth, WithRequestSigner, and
+// WithTLSClientCert in any order: it locates and clones the underlying *http.Transport itself
+// rather than replacing whatever Transport is already set.
+func WithConnectTimeout(d time.Duration) ProtocolOption {
+	return func(p *Protocol) error {
+		if p == nil {
+			return fmt.Errorf("http connect timeout option can not set nil protocol")
+		}
+		if d <= 0 {
+			return fmt.Errorf("http connect timeout option was given a non-positive duration: %s", d)
+		}
+		if p.Client == nil {
+			p.Client = &http.Client{}
+		}
+
+		dialer := &net.Dialer{Timeout: d}
+		transport, err := withHTTPTransport(p.Client.Transport, func(t *http.Transport) {
+			t.DialContext = dialer.DialContext
+		})
+		if err != nil {
+			return fmt.Errorf("http connect timeout option: %w", err)
+		}
+		p.Client.Transport = transport
+		return nil
+	}
+}